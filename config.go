@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// config is the optional user config file, e.g.
+//
+//	{"keys": {"quit": ["ctrl+c"]}, "spinner_style": "Globe"}
+type config struct {
+	// Keys overrides the default keybindings; see applyKeyConfig.
+	Keys map[string][]string `json:"keys"`
+	// SpinnerStyle names the spinner shown at startup; see spinnerStyleIndex.
+	SpinnerStyle string `json:"spinner_style"`
+}
+
+// configPath returns the location of the config file,
+// e.g. ~/.config/bubbletea-app-template/config.json on Linux.
+func configPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bubbletea-app-template", "config.json"), nil
+}
+
+// loadConfig reads the config file. A missing file, or no config directory
+// at all, yields the zero config.
+func loadConfig() (config, error) {
+	path, err := configPath()
+	if err != nil {
+		return config{}, nil
+	}
+	return loadConfigFile(path)
+}
+
+// loadConfigFile is loadConfig for the config at path.
+func loadConfigFile(path string) (config, error) {
+	var cfg config
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("could not read config: %w", err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("could not parse config in %s: %w", path, err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		cfg, err := loadConfigFile(filepath.Join(t.TempDir(), "config.json"))
+		if err != nil {
+			t.Fatalf("loadConfigFile: %v", err)
+		}
+		if !reflect.DeepEqual(cfg, config{}) {
+			t.Errorf("cfg = %+v, want the zero config", cfg)
+		}
+	})
+
+	t.Run("valid file", func(t *testing.T) {
+		path := writeConfig(t, `{"keys": {"help": ["h"]}, "spinner_style": "Globe"}`)
+		cfg, err := loadConfigFile(path)
+		if err != nil {
+			t.Fatalf("loadConfigFile: %v", err)
+		}
+		want := config{Keys: map[string][]string{"help": {"h"}}, SpinnerStyle: "Globe"}
+		if !reflect.DeepEqual(cfg, want) {
+			t.Errorf("cfg = %+v, want %+v", cfg, want)
+		}
+	})
+
+	t.Run("malformed file", func(t *testing.T) {
+		path := writeConfig(t, `{"keys": {"help": "h"}}`)
+		if _, err := loadConfigFile(path); err == nil {
+			t.Fatal("loadConfigFile = nil error, want error")
+		}
+	})
+}
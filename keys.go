@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// forceQuitKeys always quits, whatever the keybinding config says, so a bad
+// config can never leave the app without a way out.
+var forceQuitKeys = key.NewBinding(
+	key.WithKeys("ctrl+c"),
+	key.WithHelp("ctrl+c", "quit"),
+)
+
+var quitKeys = key.NewBinding(
+	key.WithKeys("q", "esc", "ctrl+c"),
+	key.WithHelp("q", "quit"),
+)
+
+var helpKeys = key.NewBinding(
+	key.WithKeys("?"),
+	key.WithHelp("?", "toggle help"),
+)
+
+var closeHelpKeys = key.NewBinding(
+	key.WithKeys("esc"),
+	key.WithHelp("esc", "close help"),
+)
+
+var spinnerKeys = key.NewBinding(
+	key.WithKeys("s"),
+	key.WithHelp("s", "next spinner style"),
+)
+
+// keyMode is a set of app states in which a binding is active.
+type keyMode int
+
+const (
+	mainMode keyMode = 1 << iota
+	helpMode
+)
+
+// keyModeNames titles each mode's section in the help overlay.
+var keyModeNames = []struct {
+	mode keyMode
+	name string
+}{
+	{mainMode, "Main"},
+	{helpMode, "Help overlay"},
+}
+
+// keyBindings lists every binding shown in the help overlay, keyed by the
+// name used for it in the "keys" section of the config file.
+var keyBindings = []struct {
+	name    string
+	binding *key.Binding
+	modes   keyMode
+}{
+	{"help", &helpKeys, mainMode | helpMode},
+	{"close_help", &closeHelpKeys, helpMode},
+	{"spinner", &spinnerKeys, mainMode},
+	{"quit", &quitKeys, mainMode},
+}
+
+// applyKeyConfig overrides the default bindings with cfg, which maps a
+// binding name to its keys, e.g. {"quit": ["ctrl+c"]}. Bindings missing from
+// cfg keep their defaults. The whole of cfg is validated before any binding
+// is touched, so an invalid config leaves the defaults in place.
+func applyKeyConfig(cfg map[string][]string) error {
+	for name, keys := range cfg {
+		known := false
+		for _, kb := range keyBindings {
+			known = known || kb.name == name
+		}
+		if !known {
+			return fmt.Errorf("unknown keybinding %q", name)
+		}
+		if len(keys) == 0 {
+			return fmt.Errorf("keybinding %q has no keys", name)
+		}
+		for _, k := range keys {
+			if k == "space" {
+				return fmt.Errorf("keybinding %q: invalid key %q, write the space bar as \" \"", name, k)
+			}
+			if !validKey(k) {
+				return fmt.Errorf("keybinding %q: invalid key %q", name, k)
+			}
+		}
+	}
+
+	// Two bindings active in the same mode can't share a key: the one
+	// checked first in Update would make the other unreachable.
+	owners := map[keyMode]map[string]string{mainMode: {}, helpMode: {}}
+	for _, kb := range keyBindings {
+		keys, ok := cfg[kb.name]
+		if !ok {
+			keys = kb.binding.Keys()
+		}
+		for _, k := range keys {
+			for _, reserved := range forceQuitKeys.Keys() {
+				if k == reserved && kb.binding != &quitKeys {
+					return fmt.Errorf("keybinding %q: key %q is reserved for quitting", kb.name, k)
+				}
+			}
+			for mode, owner := range owners {
+				if kb.modes&mode == 0 {
+					continue
+				}
+				if other, taken := owner[k]; taken && other != kb.name {
+					return fmt.Errorf("key %q is bound to both %q and %q", k, other, kb.name)
+				}
+				owner[k] = kb.name
+			}
+		}
+	}
+
+	for _, kb := range keyBindings {
+		if keys, ok := cfg[kb.name]; ok {
+			kb.binding.SetKeys(keys...)
+			kb.binding.SetHelp(keys[0], kb.binding.Help().Desc)
+		}
+	}
+	return nil
+}
+
+// namedKeys holds the names bubbletea gives to non-rune keys, e.g. "enter"
+// or "ctrl+a", as reported by tea.KeyMsg.String.
+//
+// bubbletea doesn't export its table of key names, so this walks the
+// KeyType constants instead. That relies on their layout as of v0.25.0:
+// control keys count up from KeyCtrlAt (0) to KeyBackspace (127), and the
+// remaining keys count down from KeyRunes (-1) to KeyF20. A new key type
+// outside that range would be rejected by validKey; TestValidKey checks
+// names at both ends so a bubbletea upgrade that breaks this fails there.
+var namedKeys = func() map[string]bool {
+	names := map[string]bool{}
+	for t := tea.KeyF20; t <= tea.KeyBackspace; t++ {
+		if t == tea.KeyRunes {
+			continue
+		}
+		if s := (tea.Key{Type: t}).String(); s != "" {
+			names[s] = true
+		}
+	}
+	return names
+}()
+
+// validKey reports whether k can ever match a key press: either a single
+// character or one of bubbletea's key names, optionally prefixed by "alt+".
+func validKey(k string) bool {
+	k = strings.TrimPrefix(k, "alt+")
+	return namedKeys[k] || utf8.RuneCountInString(k) == 1
+}
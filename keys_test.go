@@ -0,0 +1,93 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// resetKeyBindings restores the package-level bindings once the test is
+// done, since applyKeyConfig changes them in place.
+func resetKeyBindings(t *testing.T) {
+	t.Helper()
+	saved := make([]key.Binding, len(keyBindings))
+	for i, kb := range keyBindings {
+		saved[i] = *kb.binding
+	}
+	t.Cleanup(func() {
+		for i, kb := range keyBindings {
+			*kb.binding = saved[i]
+		}
+	})
+}
+
+func TestApplyKeyConfig(t *testing.T) {
+	resetKeyBindings(t)
+	if err := applyKeyConfig(map[string][]string{"quit": {"x", "ctrl+c"}}); err != nil {
+		t.Fatalf("applyKeyConfig: %v", err)
+	}
+	if got, want := quitKeys.Keys(), []string{"x", "ctrl+c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("quit keys = %q, want %q", got, want)
+	}
+	if got := quitKeys.Help().Key; got != "x" {
+		t.Errorf("quit help key = %q, want %q", got, "x")
+	}
+	if got := quitKeys.Help().Desc; got != "quit" {
+		t.Errorf("quit help desc = %q, want %q", got, "quit")
+	}
+}
+
+func TestApplyKeyConfigSharedKeyAcrossModes(t *testing.T) {
+	resetKeyBindings(t)
+	// close_help is only active in the overlay, where quit isn't.
+	if err := applyKeyConfig(map[string][]string{"close_help": {"q"}}); err != nil {
+		t.Fatalf("applyKeyConfig: %v", err)
+	}
+}
+
+func TestApplyKeyConfigErrors(t *testing.T) {
+	for name, cfg := range map[string]map[string][]string{
+		"unknown name":       {"jump": {"j"}},
+		"empty list":         {"quit": {}},
+		"invalid key":        {"quit": {"ctrl-c"}},
+		"conflicting keys":   {"quit": {"?"}, "help": {"?"}},
+		"conflicts default":  {"spinner": {"?"}},
+		"reserved ctrl+c":    {"help": {"ctrl+c"}},
+		"conflict in help":   {"close_help": {"?"}},
+		"one of many is bad": {"quit": {"q", "notakey"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resetKeyBindings(t)
+			want := make([][]string, len(keyBindings))
+			for i, kb := range keyBindings {
+				want[i] = kb.binding.Keys()
+			}
+			if err := applyKeyConfig(cfg); err == nil {
+				t.Fatalf("applyKeyConfig(%v) = nil, want error", cfg)
+			}
+			for i, kb := range keyBindings {
+				if got := kb.binding.Keys(); !reflect.DeepEqual(got, want[i]) {
+					t.Errorf("%s keys = %q after a rejected config, want %q", kb.name, got, want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidKey(t *testing.T) {
+	for _, k := range []string{
+		"x", "?", "é",
+		"f20", "shift+tab", "ctrl+@", "backspace", "enter", "ctrl+c", "esc",
+		"alt+enter", "alt+x", " ",
+	} {
+		if !validKey(k) {
+			t.Errorf("validKey(%q) = false, want true", k)
+		}
+	}
+	for _, k := range []string{"", "ctrl-c", "space", "runes", "alt+", "xy", "f21"} {
+		if validKey(k) {
+			t.Errorf("validKey(%q) = true, want false", k)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
@@ -12,42 +14,141 @@ import (
 
 type errMsg error
 
+type tickMsg time.Time
+
 type model struct {
-	spinner  spinner.Model
-	quitting bool
-	err      error
+	spinner      spinner.Model
+	spinnerStyle int
+	start        time.Time
+	elapsed      time.Duration
+	width        int
+	height       int
+	showHelp     bool
+	quitting     bool
+	err          error
+}
+
+// spinnerStyles are the spinners cycled through with spinnerKeys, by the
+// name used for them in the config file.
+var spinnerStyles = []struct {
+	name    string
+	spinner spinner.Spinner
+}{
+	{"Dot", spinner.Dot},
+	{"Line", spinner.Line},
+	{"MiniDot", spinner.MiniDot},
+	{"Jump", spinner.Jump},
+	{"Pulse", spinner.Pulse},
+	{"Points", spinner.Points},
+	{"Globe", spinner.Globe},
+	{"Moon", spinner.Moon},
+	{"Monkey", spinner.Monkey},
+	{"Meter", spinner.Meter},
+	{"Hamburger", spinner.Hamburger},
+	{"Ellipsis", spinner.Ellipsis},
+}
+
+// spinnerStyleIndex returns the index in spinnerStyles of the named style,
+// ignoring case. An empty name selects the first style, Dot.
+func spinnerStyleIndex(name string) (int, error) {
+	if name == "" {
+		return 0, nil
+	}
+	for i, s := range spinnerStyles {
+		if strings.EqualFold(s.name, name) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown spinner style %q", name)
 }
 
-var quitKeys = key.NewBinding(
-	key.WithKeys("q", "esc", "ctrl+c"),
-	key.WithHelp("", "press q to quit"),
+var (
+	helpTitleStyle   = lipgloss.NewStyle().Bold(true).MarginBottom(1)
+	helpSectionStyle = lipgloss.NewStyle().Underline(true)
+	helpKeyStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).PaddingRight(2)
+	helpBoxStyle     = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(lipgloss.Color("240")).
+				Padding(1, 2)
 )
 
-func initialModel() model {
+func newSpinner(style int) spinner.Model {
 	s := spinner.New()
-	s.Spinner = spinner.Dot
+	s.Spinner = spinnerStyles[style].spinner
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
-	return model{spinner: s}
+	return s
+}
+
+func initialModel(spinnerStyle int) model {
+	return model{
+		spinner:      newSpinner(spinnerStyle),
+		spinnerStyle: spinnerStyle,
+	}
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(100*time.Millisecond, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
 }
 
 func (m model) Init() tea.Cmd {
-	return m.spinner.Tick
+	// The first tick fires as soon as the program runs and starts the
+	// clock, so time spent before p.Run isn't counted.
+	start := func() tea.Msg { return tickMsg(time.Now()) }
+	return tea.Batch(m.spinner.Tick, start)
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
 	case tea.KeyMsg:
+		if key.Matches(msg, forceQuitKeys) {
+			m.quitting = true
+			return m, tea.Quit
+		}
+		if m.showHelp {
+			// The overlay covers the app, so only closing it does anything.
+			if key.Matches(msg, helpKeys, closeHelpKeys) {
+				m.showHelp = false
+			}
+			return m, nil
+		}
+		if key.Matches(msg, helpKeys) {
+			m.showHelp = true
+			return m, nil
+		}
+		if key.Matches(msg, spinnerKeys) {
+			// A fresh spinner gets a new ID, so ticks from the old one
+			// are ignored and the frame index starts over.
+			m.spinnerStyle = (m.spinnerStyle + 1) % len(spinnerStyles)
+			m.spinner = newSpinner(m.spinnerStyle)
+			return m, m.spinner.Tick
+		}
 		if key.Matches(msg, quitKeys) {
 			m.quitting = true
 			return m, tea.Quit
 
 		}
 		return m, nil
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+
 	case errMsg:
 		m.err = msg
 		return m, nil
 
+	case tickMsg:
+		if m.quitting || m.err != nil {
+			return m, nil
+		}
+		if m.start.IsZero() {
+			m.start = time.Time(msg)
+		}
+		m.elapsed = time.Time(msg).Sub(m.start)
+		return m, tick()
+
 	default:
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
@@ -59,15 +160,68 @@ func (m model) View() string {
 	if m.err != nil {
 		return m.err.Error()
 	}
-	str := fmt.Sprintf("\n\n   %s Loading forever... %s\n\n", m.spinner.View(), quitKeys.Help().Desc)
+	if m.showHelp {
+		return helpView(m.width, m.height)
+	}
+	str := fmt.Sprintf("\n\n   %s Loading forever... (%.1fs) press %s to %s, %s for help\n\n",
+		m.spinner.View(), m.elapsed.Seconds(),
+		quitKeys.Help().Key, quitKeys.Help().Desc, helpKeys.Help().Key)
 	if m.quitting {
 		return str + "\n"
 	}
 	return str
 }
 
+// helpView renders the help overlay centered in a width by height screen,
+// with one section of bindings per mode.
+func helpView(width, height int) string {
+	keys := func(b key.Binding) string { return strings.Join(b.Keys(), "/") }
+	keyWidth := lipgloss.Width(keys(forceQuitKeys))
+	for _, kb := range keyBindings {
+		if w := lipgloss.Width(keys(*kb.binding)); w > keyWidth {
+			keyWidth = w
+		}
+	}
+	row := func(b key.Binding) string {
+		return helpKeyStyle.Width(keyWidth+2).Render(keys(b)) + b.Help().Desc + "\n"
+	}
+
+	var b strings.Builder
+	b.WriteString(helpTitleStyle.Render("Keybindings"))
+	b.WriteString("\n")
+	for _, m := range keyModeNames {
+		b.WriteString(helpSectionStyle.Render(m.name))
+		b.WriteString("\n")
+		for _, kb := range keyBindings {
+			if kb.modes&m.mode != 0 {
+				b.WriteString(row(*kb.binding))
+			}
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(helpSectionStyle.Render("Anywhere"))
+	b.WriteString("\n")
+	b.WriteString(row(forceQuitKeys))
+	b.WriteString(fmt.Sprintf("\npress %s or %s to close", helpKeys.Help().Key, closeHelpKeys.Help().Key))
+
+	box := helpBoxStyle.Render(b.String())
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box)
+}
+
 func main() {
-	p := tea.NewProgram(initialModel())
+	cfg, err := loadConfig()
+	if err == nil {
+		err = applyKeyConfig(cfg.Keys)
+	}
+	var style int
+	if err == nil {
+		style, err = spinnerStyleIndex(cfg.SpinnerStyle)
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	p := tea.NewProgram(initialModel(style))
 	if _, err := p.Run(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
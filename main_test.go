@@ -0,0 +1,254 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func runeKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func update(t *testing.T, m model, msg tea.Msg) (model, tea.Cmd) {
+	t.Helper()
+	next, cmd := m.Update(msg)
+	nm, ok := next.(model)
+	if !ok {
+		t.Fatalf("Update returned %T, want model", next)
+	}
+	return nm, cmd
+}
+
+func TestHelpOverlayToggle(t *testing.T) {
+	for _, closeKey := range []tea.KeyMsg{runeKey('?'), {Type: tea.KeyEsc}} {
+		m, _ := update(t, initialModel(0), runeKey('?'))
+		if !m.showHelp {
+			t.Fatal("? did not open the help overlay")
+		}
+		m, cmd := update(t, m, closeKey)
+		if m.showHelp {
+			t.Errorf("%s did not close the help overlay", closeKey)
+		}
+		if cmd != nil {
+			t.Errorf("%s returned a command while closing the overlay", closeKey)
+		}
+	}
+}
+
+func TestHelpOverlayIgnoresOtherKeys(t *testing.T) {
+	m, _ := update(t, initialModel(0), runeKey('?'))
+	for _, msg := range []tea.KeyMsg{runeKey('s'), runeKey('q'), runeKey('x'), {Type: tea.KeyEnter}} {
+		next, cmd := update(t, m, msg)
+		if !next.showHelp {
+			t.Errorf("%s closed the help overlay", msg)
+		}
+		if next.spinnerStyle != m.spinnerStyle {
+			t.Errorf("%s changed the spinner behind the overlay", msg)
+		}
+		if next.quitting || cmd != nil {
+			t.Errorf("%s was handled while the overlay was open", msg)
+		}
+	}
+}
+
+func TestHelpOverlayCtrlCQuits(t *testing.T) {
+	m, _ := update(t, initialModel(0), runeKey('?'))
+	m, cmd := update(t, m, tea.KeyMsg{Type: tea.KeyCtrlC})
+	if !m.quitting || cmd == nil {
+		t.Fatal("ctrl+c did not quit while the help overlay was open")
+	}
+}
+
+func TestSpinnerKeysCycleStyles(t *testing.T) {
+	m := initialModel(0)
+	for i := 1; i <= len(spinnerStyles); i++ {
+		prevID := m.spinner.ID()
+		var cmd tea.Cmd
+		m, cmd = update(t, m, runeKey('s'))
+
+		want := i % len(spinnerStyles)
+		if m.spinnerStyle != want {
+			t.Fatalf("after %d presses spinnerStyle = %d, want %d", i, m.spinnerStyle, want)
+		}
+		if cmd == nil {
+			t.Fatalf("press %d returned no command", i)
+		}
+		tick, ok := cmd().(spinner.TickMsg)
+		if !ok {
+			t.Fatalf("press %d returned %T, want spinner.TickMsg", i, cmd())
+		}
+		if tick.ID != m.spinner.ID() || tick.ID == prevID {
+			t.Errorf("press %d ticks spinner %d, want the new spinner %d", i, tick.ID, m.spinner.ID())
+		}
+	}
+}
+
+func TestSpinnerStyleIndex(t *testing.T) {
+	for name, want := range map[string]string{
+		"":         "Dot",
+		"Dot":      "Dot",
+		"Globe":    "Globe",
+		"globe":    "Globe",
+		"ELLIPSIS": "Ellipsis",
+	} {
+		got, err := spinnerStyleIndex(name)
+		if err != nil {
+			t.Errorf("spinnerStyleIndex(%q): %v", name, err)
+			continue
+		}
+		if spinnerStyles[got].name != want {
+			t.Errorf("spinnerStyleIndex(%q) selects %q, want %q", name, spinnerStyles[got].name, want)
+		}
+	}
+	if _, err := spinnerStyleIndex("Wheel"); err == nil {
+		t.Error("spinnerStyleIndex(\"Wheel\") = nil error, want error")
+	}
+}
+
+func TestInitialModelSpinnerStyle(t *testing.T) {
+	style, err := spinnerStyleIndex("Globe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := initialModel(style)
+	if m.spinnerStyle != style {
+		t.Errorf("spinnerStyle = %d, want %d", m.spinnerStyle, style)
+	}
+	if !reflect.DeepEqual(m.spinner.Spinner, spinner.Globe) {
+		t.Error("initial spinner does not use the Globe style")
+	}
+}
+
+func TestTickStartsClock(t *testing.T) {
+	now := time.Now()
+	m, _ := update(t, initialModel(0), tickMsg(now))
+	if !m.start.Equal(now) {
+		t.Errorf("start = %v, want the first tick %v", m.start, now)
+	}
+	if m.elapsed != 0 {
+		t.Errorf("elapsed = %v after the first tick, want 0", m.elapsed)
+	}
+}
+
+func TestTickUpdatesElapsed(t *testing.T) {
+	m := initialModel(0)
+	m.start = time.Now()
+	m, cmd := update(t, m, tickMsg(m.start.Add(3200*time.Millisecond)))
+	if m.elapsed != 3200*time.Millisecond {
+		t.Errorf("elapsed = %v, want 3.2s", m.elapsed)
+	}
+	if view := m.View(); !strings.Contains(view, "(3.2s)") {
+		t.Errorf("View() = %q, want it to show (3.2s)", view)
+	}
+	if cmd == nil {
+		t.Fatal("tick returned no command while running")
+	}
+	if _, ok := cmd().(tickMsg); !ok {
+		t.Error("tick did not schedule another tick")
+	}
+}
+
+func TestTickStopsClock(t *testing.T) {
+	for name, stop := range map[string]func(*model){
+		"quitting": func(m *model) { m.quitting = true },
+		"error":    func(m *model) { m.err = errMsg(errors.New("boom")) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			m := initialModel(0)
+			m.start = time.Now()
+			stop(&m)
+			m, cmd := update(t, m, tickMsg(m.start.Add(time.Second)))
+			if cmd != nil {
+				t.Error("tick returned a command after the clock stopped")
+			}
+			if m.elapsed != 0 {
+				t.Errorf("elapsed = %v, want it left at 0", m.elapsed)
+			}
+		})
+	}
+}
+
+// helpSections splits a rendered help overlay into the text under each
+// section title.
+func helpSections(t *testing.T, view string) map[string]string {
+	t.Helper()
+	titles := []string{"Main", "Help overlay", "Anywhere"}
+	sections := map[string]string{}
+	for i, title := range titles {
+		start := strings.Index(view, title)
+		if start < 0 {
+			t.Fatalf("help view has no %q section:\n%s", title, view)
+		}
+		end := len(view)
+		if i+1 < len(titles) {
+			end = strings.Index(view, titles[i+1])
+		}
+		if end < start {
+			t.Fatalf("help view sections are out of order:\n%s", view)
+		}
+		sections[title] = view[start:end]
+	}
+	return sections
+}
+
+func TestHelpViewGroupsByMode(t *testing.T) {
+	view := helpView(80, 30)
+	sections := helpSections(t, view)
+	for _, kb := range keyBindings {
+		for _, m := range keyModeNames {
+			row := strings.Join(kb.binding.Keys(), "/")
+			listed := strings.Contains(sections[m.name], kb.binding.Help().Desc) &&
+				strings.Contains(sections[m.name], row)
+			if want := kb.modes&m.mode != 0; listed != want {
+				t.Errorf("%s listed under %q = %v, want %v:\n%s", kb.name, m.name, listed, want, view)
+			}
+		}
+	}
+	if !strings.Contains(sections["Anywhere"], "ctrl+c") {
+		t.Errorf("ctrl+c is not listed under \"Anywhere\":\n%s", view)
+	}
+}
+
+func TestHelpViewFitsLongKeys(t *testing.T) {
+	resetKeyBindings(t)
+	keys := []string{"ctrl+c", "esc", "q", "x", "alt+q", "ctrl+x"}
+	if err := applyKeyConfig(map[string][]string{"quit": keys}); err != nil {
+		t.Fatal(err)
+	}
+	joined := strings.Join(keys, "/")
+	view := helpView(80, 30)
+	var row string
+	for _, line := range strings.Split(view, "\n") {
+		if strings.Contains(line, "quit") && strings.Contains(line, "alt+q") {
+			row = line
+		}
+	}
+	if !strings.Contains(row, joined) {
+		t.Fatalf("quit keys %q are not on one row with their help:\n%s", joined, view)
+	}
+	// Every description starts in the same column as the quit row's.
+	col := strings.Index(row, "quit")
+	for _, desc := range []string{"toggle help", "close help", "next spinner style"} {
+		for _, line := range strings.Split(view, "\n") {
+			if i := strings.Index(line, desc); i >= 0 && i != col {
+				t.Errorf("%q starts at column %d, want %d:\n%s", desc, i, col, view)
+			}
+		}
+	}
+}
+
+func TestHelpViewFillsScreen(t *testing.T) {
+	m, _ := update(t, initialModel(0), tea.WindowSizeMsg{Width: 100, Height: 40})
+	m, _ = update(t, m, runeKey('?'))
+	view := m.View()
+	if w, h := lipgloss.Width(view), lipgloss.Height(view); w != 100 || h != 40 {
+		t.Errorf("help overlay is %dx%d, want the 100x40 window", w, h)
+	}
+}